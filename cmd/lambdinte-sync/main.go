@@ -0,0 +1,34 @@
+// Command lambdinte-sync publishes the command schemas recorded with lambdinte.DefineCommand to Discord via
+// Function.SyncCommands, so commands can be kept in sync at build/deploy time instead of from inside the
+// running Lambda function.
+//
+// This file is meant to be copied into the bot's own module, with a blank import added for the package(s)
+// whose init functions call lambdinte.DefineCommand — that's what populates lambdinte.DefaultMux's specs
+// before SyncCommands runs. Run as-is it has nothing registered and will clear the application's global
+// command list.
+//
+//	DISCORD_BOT_TOKEN=... DISCORD_APPLICATION_ID=... go run ./cmd/lambdinte-sync
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Quantaly/lambdinte"
+)
+
+func main() {
+	botToken := os.Getenv("DISCORD_BOT_TOKEN")
+	appID := os.Getenv("DISCORD_APPLICATION_ID")
+	if botToken == "" || appID == "" {
+		fmt.Fprintln(os.Stderr, "lambdinte-sync: DISCORD_BOT_TOKEN and DISCORD_APPLICATION_ID must both be set")
+		os.Exit(1)
+	}
+
+	var f lambdinte.Function
+	if err := f.SyncCommands(context.Background(), botToken, appID); err != nil {
+		fmt.Fprintln(os.Stderr, "lambdinte-sync:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,101 @@
+package lambdinte
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// CommandSpec describes a slash command's schema, as registered with Discord.
+type CommandSpec struct {
+	Name        string
+	Description string
+	Options     []*discordgo.ApplicationCommandOption
+	// DefaultPermission controls whether the command is enabled for all members by default.
+	// Leave nil to use Discord's default (enabled).
+	DefaultPermission *bool
+	// GuildID scopes this command to a single guild instead of registering it globally.
+	// Leave empty to register the command globally.
+	GuildID string
+}
+
+func (spec CommandSpec) applicationCommand() *discordgo.ApplicationCommand {
+	return &discordgo.ApplicationCommand{
+		Name:              spec.Name,
+		Description:       spec.Description,
+		Options:           spec.Options,
+		DefaultPermission: spec.DefaultPermission,
+	}
+}
+
+// DefineCommand records spec's schema on m, so that it can later be published to Discord with
+// Function.SyncCommands. DefineCommand does not register a Handler; pair it with RegisterCommand,
+// RegisterSubcommand, or RegisterSubcommandGroup using the same name.
+func (m *Mux) DefineCommand(spec CommandSpec) {
+	m.specs = append(m.specs, spec)
+}
+
+// DefineCommand records spec's schema on DefaultMux. See Mux.DefineCommand.
+func DefineCommand(spec CommandSpec) {
+	DefaultMux.DefineCommand(spec)
+}
+
+// SyncCommands publishes the command schemas recorded with DefineCommand on f.Handler (or DefaultMux, if
+// f.Handler is nil) to Discord, authenticating with botToken and appID. For each scope that has at least one
+// defined command — global, and each guild named by a CommandSpec's GuildID — SyncCommands bulk-overwrites
+// that scope's entire command list, so a command renamed or removed in code is also renamed or removed on
+// Discord rather than left behind as drift. If no global commands are defined, the global command list is
+// still overwritten with an empty list, clearing any commands registered by a previous deploy.
+//
+// SyncCommands can be called from a one-shot Lambda invocation on deploy, or from a small build-time command
+// that constructs a Function only to call this method (see cmd/lambdinte-sync). botToken and appID are taken
+// as arguments, rather than read from Function's BotToken and ApplicationID fields, so that a bare Function{}
+// works for the latter case.
+//
+// ctx is accepted for the usual reasons a network call should take one, but discordgo v0.25.0's
+// ApplicationCommandBulkOverwrite has no context parameter to forward it to, so it currently has no effect on
+// the request; it's here so that changes to discordgo's API, or a future switch to a context-aware client,
+// don't require changing SyncCommands' signature.
+func (f *Function) SyncCommands(ctx context.Context, botToken, appID string) error {
+	handler := f.Handler
+	if handler == nil {
+		handler = DefaultMux
+	}
+
+	mux, ok := handler.(*Mux)
+	if !ok {
+		return fmt.Errorf("lambdinte: SyncCommands requires Handler to be a *Mux, got %T", handler)
+	}
+
+	session, err := discordgo.New("Bot " + botToken)
+	if err != nil {
+		return err
+	}
+
+	byGuild := map[string][]*discordgo.ApplicationCommand{"": {}}
+	for _, spec := range mux.specs {
+		byGuild[spec.GuildID] = append(byGuild[spec.GuildID], spec.applicationCommand())
+	}
+
+	// The global scope is always synced first; guild scopes follow in no particular order.
+	scopes := []string{""}
+	for guildID := range byGuild {
+		if guildID != "" {
+			scopes = append(scopes, guildID)
+		}
+	}
+
+	for _, guildID := range scopes {
+		commands := byGuild[guildID]
+		if _, err := session.ApplicationCommandBulkOverwrite(appID, guildID, commands); err != nil {
+			scope := guildID
+			if scope == "" {
+				scope = "global"
+			}
+			return fmt.Errorf("lambdinte: syncing %s commands: %w", scope, err)
+		}
+	}
+
+	return nil
+}
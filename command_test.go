@@ -0,0 +1,127 @@
+package lambdinte
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// withTestEndpoint points discordgo's REST calls at server for the duration of the test, restoring the
+// original endpoint afterward.
+func withTestEndpoint(t *testing.T, server *httptest.Server) {
+	t.Helper()
+
+	original := discordgo.EndpointApplications
+	discordgo.EndpointApplications = server.URL + "/applications"
+	t.Cleanup(func() {
+		discordgo.EndpointApplications = original
+	})
+}
+
+func TestSyncCommandsRequiresMux(t *testing.T) {
+	f := &Function{
+		Handler: HandlerFunc(DefaultPingHandlerFunc),
+	}
+
+	if err := f.SyncCommands(context.Background(), "token", "app-id"); err == nil {
+		t.Fatal("expected an error when Handler is not a *Mux")
+	}
+}
+
+func TestDefineCommandRecordsSpec(t *testing.T) {
+	var mux Mux
+	mux.DefineCommand(CommandSpec{Name: "ping", Description: "pong"})
+
+	if len(mux.specs) != 1 || mux.specs[0].Name != "ping" {
+		t.Fatalf("got specs %v, want one spec named ping", mux.specs)
+	}
+}
+
+func TestSyncCommandsBulkOverwritesEachScope(t *testing.T) {
+	type request struct {
+		path     string
+		method   string
+		commands []*discordgo.ApplicationCommand
+	}
+	var requests []request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var commands []*discordgo.ApplicationCommand
+		if err := json.NewDecoder(r.Body).Decode(&commands); err != nil {
+			t.Errorf("decoding request body: %v", err)
+		}
+		requests = append(requests, request{path: r.URL.Path, method: r.Method, commands: commands})
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(commands)
+	}))
+	defer server.Close()
+	withTestEndpoint(t, server)
+
+	var mux Mux
+	mux.DefineCommand(CommandSpec{Name: "ping", Description: "pong"})
+	mux.DefineCommand(CommandSpec{Name: "pong", Description: "ping"})
+	mux.DefineCommand(CommandSpec{Name: "admin", Description: "guild-only", GuildID: "guild-1"})
+
+	f := &Function{Handler: &mux}
+	if err := f.SyncCommands(context.Background(), "test-token", "app-id"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2 (global and guild-1)", len(requests))
+	}
+
+	var global, guild *request
+	for i := range requests {
+		req := &requests[i]
+		if strings.Contains(req.path, "/guilds/guild-1/") {
+			guild = req
+		} else {
+			global = req
+		}
+	}
+
+	if global == nil {
+		t.Fatal("no request hit the global commands endpoint")
+	}
+	if global.method != http.MethodPut {
+		t.Errorf("got global method %q, want PUT", global.method)
+	}
+	if len(global.commands) != 2 {
+		t.Fatalf("got %d global commands, want 2", len(global.commands))
+	}
+
+	if guild == nil {
+		t.Fatal("no request hit the guild-1 commands endpoint")
+	}
+	if len(guild.commands) != 1 || guild.commands[0].Name != "admin" {
+		t.Fatalf("got guild-1 commands %v, want one command named admin", guild.commands)
+	}
+}
+
+func TestSyncCommandsSurfacesBulkOverwriteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"message": "something broke", "code": 0}`))
+	}))
+	defer server.Close()
+	withTestEndpoint(t, server)
+
+	var mux Mux
+	mux.DefineCommand(CommandSpec{Name: "admin", Description: "guild-only", GuildID: "guild-1"})
+
+	f := &Function{Handler: &mux}
+	err := f.SyncCommands(context.Background(), "test-token", "app-id")
+	if err == nil {
+		t.Fatal("expected an error when the bulk overwrite fails")
+	}
+	if !strings.Contains(err.Error(), "global") {
+		t.Errorf("got error %q, want it to name the global scope (it fails first)", err.Error())
+	}
+}
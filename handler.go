@@ -25,9 +25,17 @@ type handlerMux struct {
 	// eh probably not
 
 	handlers map[string]Handler
+	mw       []Middleware
 }
 
-// Register registers the handler for the given key.
+// Use registers middleware that wraps every handler registered afterward via Register or RegisterFunc.
+// It does not apply retroactively to handlers already registered. Middleware is composed in registration
+// order, so the first middleware passed to Use runs outermost.
+func (h *handlerMux) Use(mw ...Middleware) {
+	h.mw = append(h.mw, mw...)
+}
+
+// Register registers the handler for the given key, wrapped in any middleware previously added with Use.
 // If a handler already exists for key, Handle panics.
 func (h *handlerMux) Register(key string, handler Handler) {
 	if handler == nil {
@@ -36,8 +44,11 @@ func (h *handlerMux) Register(key string, handler Handler) {
 	if _, exists := h.handlers[key]; exists {
 		panic("lambdinte: multiple registrations for " + key)
 	}
+	if h.handlers == nil {
+		h.handlers = make(map[string]Handler)
+	}
 
-	h.handlers[key] = handler
+	h.handlers[key] = chain(h.mw, handler)
 }
 
 // RegisterFunc registers the handler function for the given key.
@@ -53,29 +64,69 @@ func (h *handlerMux) RegisterFunc(name string, handler func(context.Context, dis
 // It is appropriate for both APPLICATION_COMMAND interactions (type 2) and APPLICATION_COMMAND_AUTOCOMPLETE interactions (type 4).
 type ApplicationCommandMux struct {
 	handlerMux
+
+	subcommands map[string]Handler
 }
 
 // Handle handles the interaction. If the interaction is of the wrong type, or the command name has not been registered, it panics.
+// If the interaction names a subcommand or subcommand group registered with RegisterSubcommand or RegisterSubcommandGroup,
+// it is dispatched there instead, with evt's data adjusted so that the leaf subcommand's options are directly accessible.
 func (m *ApplicationCommandMux) Handle(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
 	if evt.Type != discordgo.InteractionApplicationCommand && evt.Type != discordgo.InteractionApplicationCommandAutocomplete {
 		panic("lambdinte: ApplicationCommandMux asked to handle interaction of wrong type " + evt.Type.String())
 	}
 
-	name := evt.ApplicationCommandData().Name
-	if handler, ok := m.handlers[name]; ok {
+	data := evt.ApplicationCommandData()
+	group, sub, options := subcommandPath(data)
+	if sub != "" {
+		if handler, ok := m.subcommands[subcommandKey(data.Name, group, sub)]; ok {
+			data.Name = sub
+			data.Options = options
+			evt.Data = data
+			return handler.Handle(ctx, evt)
+		}
+	}
+
+	if handler, ok := m.handlers[data.Name]; ok {
 		return handler.Handle(ctx, evt)
 	}
 
-	panic("lambdinte: ApplicationCommandMux asked to handle unknown command " + name)
+	panic("lambdinte: ApplicationCommandMux asked to handle unknown command " + data.Name)
+}
+
+// With returns a registrar that applies mw to the single handler passed to its next Register or RegisterFunc call,
+// in addition to any middleware registered on m itself via Use.
+func (m *ApplicationCommandMux) With(mw ...Middleware) *ApplicationCommandRegistrar {
+	return &ApplicationCommandRegistrar{mux: m, mw: mw}
+}
+
+// ApplicationCommandRegistrar registers a single application command handler wrapped in scoped middleware.
+// Obtain one from ApplicationCommandMux.With.
+type ApplicationCommandRegistrar struct {
+	mux *ApplicationCommandMux
+	mw  []Middleware
+}
+
+// Register registers handler for name, wrapped in the registrar's middleware.
+func (r *ApplicationCommandRegistrar) Register(name string, handler Handler) {
+	r.mux.Register(name, chain(r.mw, handler))
+}
+
+// RegisterFunc registers the handler function for name, wrapped in the registrar's middleware.
+func (r *ApplicationCommandRegistrar) RegisterFunc(name string, handler func(context.Context, discordgo.Interaction) (discordgo.InteractionResponse, error)) {
+	r.Register(name, HandlerFunc(handler))
 }
 
 // MessageComponentMux stores and selects handlers for message component interactions based on their custom_id.
 // If you use custom_id for any purpose other than identifying the component, such as persisting state, it is probably not appropriate.
 type MessageComponentMux struct {
 	handlerMux
+	prefixMux
 }
 
-// Handle handles the interaction. If the interaction is of the wrong type, or the component ID has not been registered, it panics.
+// Handle handles the interaction. If the interaction is of the wrong type, or the component ID has not been
+// registered (exactly or by prefix), it panics. An exact match registered with Register takes precedence over
+// any prefix match registered with RegisterPrefix.
 func (m *MessageComponentMux) Handle(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
 	if evt.Type != discordgo.InteractionMessageComponent {
 		panic("lambdinte: MessageComponentMux asked to handle interaction of wrong type " + evt.Type.String())
@@ -86,16 +137,58 @@ func (m *MessageComponentMux) Handle(ctx context.Context, evt discordgo.Interact
 		return handler.Handle(ctx, evt)
 	}
 
+	if handler, params, ok := m.matchPrefix(customID); ok {
+		return handler.Handle(context.WithValue(ctx, customIDParamsKey{}, params), evt)
+	}
+
 	panic("lambdinte: MessageComponentMux asked to handle unknown ID " + customID)
 }
 
+// RegisterPrefix registers handler for any custom_id that begins with prefix, either exactly or followed by
+// CustomIDDelimiter. The segments of the custom_id following prefix (and its delimiter, if present) are
+// available to handler via CustomIDParams. If a prefix handler already exists for prefix, RegisterPrefix panics.
+func (m *MessageComponentMux) RegisterPrefix(prefix string, handler Handler) {
+	m.registerPrefix(prefix, chain(m.mw, handler))
+}
+
+// RegisterPrefixFunc registers the handler function for any custom_id that begins with prefix. See RegisterPrefix.
+func (m *MessageComponentMux) RegisterPrefixFunc(prefix string, handler func(context.Context, discordgo.Interaction) (discordgo.InteractionResponse, error)) {
+	m.RegisterPrefix(prefix, HandlerFunc(handler))
+}
+
+// With returns a registrar that applies mw to the single handler passed to its next Register or RegisterFunc call,
+// in addition to any middleware registered on m itself via Use.
+func (m *MessageComponentMux) With(mw ...Middleware) *MessageComponentRegistrar {
+	return &MessageComponentRegistrar{mux: m, mw: mw}
+}
+
+// MessageComponentRegistrar registers a single message component handler wrapped in scoped middleware.
+// Obtain one from MessageComponentMux.With.
+type MessageComponentRegistrar struct {
+	mux *MessageComponentMux
+	mw  []Middleware
+}
+
+// Register registers handler for customID, wrapped in the registrar's middleware.
+func (r *MessageComponentRegistrar) Register(customID string, handler Handler) {
+	r.mux.Register(customID, chain(r.mw, handler))
+}
+
+// RegisterFunc registers the handler function for customID, wrapped in the registrar's middleware.
+func (r *MessageComponentRegistrar) RegisterFunc(customID string, handler func(context.Context, discordgo.Interaction) (discordgo.InteractionResponse, error)) {
+	r.Register(customID, HandlerFunc(handler))
+}
+
 // ModalSubmitMux stores and selects handlers for modal submit interactions based on their custom_id.
 // If you use custom_id for any purpose other than identifying the modal, such as persisting state, it is probably not appropriate.
 type ModalSubmitMux struct {
 	handlerMux
+	prefixMux
 }
 
-// Handle handles the interaction. If the interaction is of the wrong type, or the modal ID has not been registered, it panics.
+// Handle handles the interaction. If the interaction is of the wrong type, or the modal ID has not been
+// registered (exactly or by prefix), it panics. An exact match registered with Register takes precedence over
+// any prefix match registered with RegisterPrefix.
 func (m *ModalSubmitMux) Handle(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
 	if evt.Type != discordgo.InteractionModalSubmit {
 		panic("lambdinte: ModalSubmitMux asked to handle interaction of wrong type " + evt.Type.String())
@@ -106,9 +199,48 @@ func (m *ModalSubmitMux) Handle(ctx context.Context, evt discordgo.Interaction)
 		return handler.Handle(ctx, evt)
 	}
 
+	if handler, params, ok := m.matchPrefix(customID); ok {
+		return handler.Handle(context.WithValue(ctx, customIDParamsKey{}, params), evt)
+	}
+
 	panic("lambdinte: ModalSubmitMux asked to handle unknown ID " + customID)
 }
 
+// RegisterPrefix registers handler for any custom_id that begins with prefix, either exactly or followed by
+// CustomIDDelimiter. The segments of the custom_id following prefix (and its delimiter, if present) are
+// available to handler via CustomIDParams. If a prefix handler already exists for prefix, RegisterPrefix panics.
+func (m *ModalSubmitMux) RegisterPrefix(prefix string, handler Handler) {
+	m.registerPrefix(prefix, chain(m.mw, handler))
+}
+
+// RegisterPrefixFunc registers the handler function for any custom_id that begins with prefix. See RegisterPrefix.
+func (m *ModalSubmitMux) RegisterPrefixFunc(prefix string, handler func(context.Context, discordgo.Interaction) (discordgo.InteractionResponse, error)) {
+	m.RegisterPrefix(prefix, HandlerFunc(handler))
+}
+
+// With returns a registrar that applies mw to the single handler passed to its next Register or RegisterFunc call,
+// in addition to any middleware registered on m itself via Use.
+func (m *ModalSubmitMux) With(mw ...Middleware) *ModalSubmitRegistrar {
+	return &ModalSubmitRegistrar{mux: m, mw: mw}
+}
+
+// ModalSubmitRegistrar registers a single modal submit handler wrapped in scoped middleware.
+// Obtain one from ModalSubmitMux.With.
+type ModalSubmitRegistrar struct {
+	mux *ModalSubmitMux
+	mw  []Middleware
+}
+
+// Register registers handler for customID, wrapped in the registrar's middleware.
+func (r *ModalSubmitRegistrar) Register(customID string, handler Handler) {
+	r.mux.Register(customID, chain(r.mw, handler))
+}
+
+// RegisterFunc registers the handler function for customID, wrapped in the registrar's middleware.
+func (r *ModalSubmitRegistrar) RegisterFunc(customID string, handler func(context.Context, discordgo.Interaction) (discordgo.InteractionResponse, error)) {
+	r.Register(customID, HandlerFunc(handler))
+}
+
 // Mux routes interactions to Handlers based on their types.
 type Mux struct {
 	// PingHandler is called for PING interactions (type 1).
@@ -126,11 +258,25 @@ type Mux struct {
 	// ModalSubmitHandler is called for MODAL_SUBMIT interactions (type 5).
 	// You may want to use RegisterModal and/or RegisterModalFunc to set it up.
 	ModalSubmitHandler Handler
+
+	mw    []Middleware
+	specs []CommandSpec
 }
 
-// Handle forwards to the appropriate Handler.
+// Use registers middleware that wraps every interaction dispatched by m, regardless of type.
+// Middleware is composed in registration order, so the first middleware passed to Use runs outermost.
+func (m *Mux) Use(mw ...Middleware) {
+	m.mw = append(m.mw, mw...)
+}
+
+// Handle forwards to the appropriate Handler, wrapped in any middleware registered with Use.
 // If an interaction is received of a type that Mux does not have a handler for (other than PING), Handle panics.
 func (m *Mux) Handle(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+	return chain(m.mw, HandlerFunc(m.dispatch)).Handle(ctx, evt)
+}
+
+// dispatch forwards to the appropriate Handler without applying m.mw.
+func (m *Mux) dispatch(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
 	switch evt.Type {
 	case discordgo.InteractionPing:
 		if m.PingHandler == nil {
@@ -263,6 +409,11 @@ var DefaultMux = &defaultMux
 
 var defaultMux Mux
 
+// Use registers middleware that wraps every interaction dispatched by DefaultMux, regardless of type.
+func Use(mw ...Middleware) {
+	DefaultMux.Use(mw...)
+}
+
 // RegisterCommand registers the handler for application command interactions with the given name into DefaultMux.
 func RegisterCommand(name string, handler Handler) {
 	DefaultMux.RegisterCommand(name, handler)
@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"io"
 	"strings"
+	"sync"
 
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/bwmarrin/discordgo"
@@ -23,6 +24,36 @@ type Function struct {
 	PublicKey ed25519.PublicKey
 	// Handler handles incoming interaction events; if it is nil, DefaultMux will be used.
 	Handler Handler
+	// BotToken, if set, authenticates the Responder made available via FromContext, so handlers can defer
+	// their response and follow up later through Discord's webhook endpoints.
+	BotToken string
+	// ApplicationID is this bot's application ID. It is only required alongside BotToken on Discord API
+	// versions where Interaction.AppID isn't populated; when empty, the Responder uses Interaction.AppID instead.
+	ApplicationID string
+
+	session     *discordgo.Session
+	sessionOnce sync.Once
+}
+
+// responder returns a Responder for interaction if BotToken is set, or nil otherwise.
+func (f *Function) responder(interaction *discordgo.Interaction) *Responder {
+	if f.BotToken == "" {
+		return nil
+	}
+
+	f.sessionOnce.Do(func() {
+		var err error
+		f.session, err = discordgo.New("Bot " + f.BotToken)
+		if err != nil {
+			panic("lambdinte: failed to create Discord session: " + err.Error())
+		}
+	})
+
+	if f.ApplicationID != "" {
+		interaction.AppID = f.ApplicationID
+	}
+
+	return &Responder{session: f.session, interaction: interaction}
 }
 
 type incomingEvent struct {
@@ -103,9 +134,30 @@ func (f *Function) invoke(ctx context.Context, evt incomingEvent) (res outgoingR
 		return
 	}
 
+	responder := f.responder(&interaction)
+	if responder != nil {
+		ctx = context.WithValue(ctx, responderKey{}, responder)
+	}
+
+	// dispatched starts out as f.Handler itself, in case it handles the interaction directly. If it's a
+	// Mux, or any mux built from handlerMux, dispatching further into a registered command/component/modal
+	// handler overwrites dispatched with that handler via chain (see recordDispatchedHandler), so we can
+	// still find the concrete Handler that actually served the interaction — and check whether it's a
+	// DeferredHandler — however many muxes deep it was registered.
+	dispatched := f.Handler
+	ctx = context.WithValue(ctx, dispatchedHandlerKey{}, &dispatched)
+
 	res.StatusCode = 200
 	res.Response = new(discordgo.InteractionResponse)
 	*res.Response, err = f.Handler.Handle(ctx, interaction)
+	if err != nil {
+		return
+	}
+
+	if deferred, ok := dispatched.(DeferredHandler); ok && responder != nil {
+		go deferred.HandleDeferred(ctx, interaction, responder)
+	}
+
 	return
 }
 
@@ -0,0 +1,142 @@
+package lambdinte
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func signedEvent(t *testing.T, priv ed25519.PrivateKey, body []byte) incomingEvent {
+	t.Helper()
+
+	timestamp := "1234567890"
+	signed := append([]byte(timestamp), body...)
+	sig := ed25519.Sign(priv, signed)
+
+	return incomingEvent{
+		Body: string(body),
+		Headers: map[string]string{
+			"X-Signature-Ed25519":   base64.StdEncoding.EncodeToString(sig),
+			"X-Signature-Timestamp": timestamp,
+		},
+	}
+}
+
+type deferredHandlerFunc struct {
+	handle   func(context.Context, discordgo.Interaction) (discordgo.InteractionResponse, error)
+	deferred func(context.Context, discordgo.Interaction, *Responder)
+}
+
+func (h deferredHandlerFunc) Handle(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+	return h.handle(ctx, evt)
+}
+
+func (h deferredHandlerFunc) HandleDeferred(ctx context.Context, evt discordgo.Interaction, r *Responder) {
+	h.deferred(ctx, evt, r)
+}
+
+func TestFunctionInvokeDoesNotBlockOnDeferredHandler(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	body, err := json.Marshal(discordgo.Interaction{Type: discordgo.InteractionApplicationCommand})
+	if err != nil {
+		t.Fatalf("marshaling interaction: %v", err)
+	}
+
+	started := make(chan struct{})
+	var ran int32
+	f := &Function{
+		PublicKey: pub,
+		BotToken:  "test-token",
+		Handler: deferredHandlerFunc{
+			handle: func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+				r := FromContext(ctx)
+				if r == nil {
+					t.Error("FromContext returned nil with BotToken set")
+				}
+				return r.Defer(0), nil
+			},
+			deferred: func(ctx context.Context, evt discordgo.Interaction, r *Responder) {
+				close(started)
+				time.Sleep(50 * time.Millisecond)
+				atomic.StoreInt32(&ran, 1)
+			},
+		},
+	}
+
+	res, err := f.invoke(context.Background(), signedEvent(t, priv, body))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Response.Type != discordgo.InteractionResponseDeferredChannelMessageWithSource {
+		t.Fatalf("got response type %v, want deferred", res.Response.Type)
+	}
+	if atomic.LoadInt32(&ran) != 0 {
+		t.Fatal("invoke waited for HandleDeferred to finish before returning")
+	}
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("HandleDeferred never started")
+	}
+}
+
+func TestFunctionInvokeFindsDeferredHandlerRegisteredOnMux(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	body, err := json.Marshal(discordgo.Interaction{
+		Type: discordgo.InteractionApplicationCommand,
+		Data: discordgo.ApplicationCommandInteractionData{Name: "slow"},
+	})
+	if err != nil {
+		t.Fatalf("marshaling interaction: %v", err)
+	}
+
+	started := make(chan struct{})
+	var mux Mux
+	mux.RegisterCommand("slow", deferredHandlerFunc{
+		handle: func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+			r := FromContext(ctx)
+			if r == nil {
+				t.Error("FromContext returned nil with BotToken set")
+			}
+			return r.Defer(0), nil
+		},
+		deferred: func(ctx context.Context, evt discordgo.Interaction, r *Responder) {
+			close(started)
+		},
+	})
+
+	f := &Function{
+		PublicKey: pub,
+		BotToken:  "test-token",
+		Handler:   &mux,
+	}
+
+	res, err := f.invoke(context.Background(), signedEvent(t, priv, body))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Response.Type != discordgo.InteractionResponseDeferredChannelMessageWithSource {
+		t.Fatalf("got response type %v, want deferred", res.Response.Type)
+	}
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("HandleDeferred was never invoked for the command handler registered via RegisterCommand")
+	}
+}
@@ -0,0 +1,157 @@
+package lambdinte
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Middleware wraps a Handler to add cross-cutting behavior, such as logging, panic recovery, or rate limiting.
+// Middlewares registered together are composed in registration order: the first-registered middleware runs outermost.
+type Middleware func(Handler) Handler
+
+// dispatchedHandlerKey is the context key under which chain stashes a pointer to the concrete, unwrapped
+// Handler it's about to invoke, so that Function.invoke can later recover it (see recordDispatchedHandler).
+type dispatchedHandlerKey struct{}
+
+// recordDispatchedHandler records handler as the concrete Handler being dispatched to, if ctx was set up by
+// Function.invoke to receive one. It's a no-op otherwise, so chain works the same whether or not anyone is
+// watching for the dispatched handler.
+func recordDispatchedHandler(ctx context.Context, handler Handler) {
+	if box, ok := ctx.Value(dispatchedHandlerKey{}).(*Handler); ok {
+		*box = handler
+	}
+}
+
+// chain wraps handler in mw, with mw[0] running outermost. The handler passed in — not the composed result of
+// wrapping it in mw — is what recordDispatchedHandler reports, since that's the registered Handler whose
+// concrete type (e.g. DeferredHandler) callers care about; middleware only ever returns a plain Handler.
+func chain(mw []Middleware, handler Handler) Handler {
+	wrapped := HandlerFunc(func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		recordDispatchedHandler(ctx, handler)
+		return handler.Handle(ctx, evt)
+	})
+
+	result := Handler(wrapped)
+	for i := len(mw) - 1; i >= 0; i-- {
+		result = mw[i](result)
+	}
+	return result
+}
+
+// RecoverMiddleware returns a Middleware that recovers from panics in the wrapped Handler, logs them,
+// and responds with a generic ephemeral error message instead of letting the panic escape and crash the Lambda invocation.
+func RecoverMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, evt discordgo.Interaction) (res discordgo.InteractionResponse, err error) {
+			defer func() {
+				if p := recover(); p != nil {
+					log.Printf("lambdinte: recovered from panic handling interaction %s: %v", evt.ID, p)
+					res, err = errorResponse(evt), nil
+				}
+			}()
+
+			return next.Handle(ctx, evt)
+		})
+	}
+}
+
+// errorResponse builds a generic "something went wrong" response appropriate for evt's type.
+func errorResponse(evt discordgo.Interaction) discordgo.InteractionResponse {
+	return ephemeralMessage(evt, "Something went wrong.")
+}
+
+// ephemeralMessage builds a response carrying content, visible only to the invoking user, shaped
+// appropriately for evt's type. Autocomplete interactions can only be answered with
+// InteractionApplicationCommandAutocompleteResult, so for those evt's content is dropped in favor of an
+// empty choice list rather than producing a response Discord would reject as malformed.
+func ephemeralMessage(evt discordgo.Interaction, content string) discordgo.InteractionResponse {
+	if evt.Type == discordgo.InteractionApplicationCommandAutocomplete {
+		return discordgo.InteractionResponse{
+			Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+			Data: &discordgo.InteractionResponseData{
+				Choices: []*discordgo.ApplicationCommandOptionChoice{},
+			},
+		}
+	}
+
+	return discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   uint64(discordgo.MessageFlagsEphemeral),
+		},
+	}
+}
+
+// LoggingMiddleware returns a Middleware that logs each interaction's type and the wrapped Handler's duration.
+func LoggingMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+			start := time.Now()
+			res, err := next.Handle(ctx, evt)
+			log.Printf("lambdinte: handled %s interaction %s in %s (err=%v)", evt.Type.String(), evt.ID, time.Since(start), err)
+			return res, err
+		})
+	}
+}
+
+// interactionUserID returns the ID of the user who triggered evt, checking both Member.User (guild interactions)
+// and User (DM interactions). It returns "" if neither is present.
+func interactionUserID(evt discordgo.Interaction) string {
+	if evt.Member != nil && evt.Member.User != nil {
+		return evt.Member.User.ID
+	}
+	if evt.User != nil {
+		return evt.User.ID
+	}
+	return ""
+}
+
+// RateLimitMiddleware returns a Middleware that allows each user at most limit interactions per per duration,
+// keyed off interactionUserID. Requests beyond the limit are rejected with an ephemeral error response
+// (or, for autocomplete interactions, an empty choice list — see ephemeralMessage) and do not reach the
+// wrapped Handler. Buckets for users whose window has expired are swept out as new interactions come in, so
+// buckets doesn't grow without bound across a long-lived Lambda execution environment.
+func RateLimitMiddleware(limit int, per time.Duration) Middleware {
+	type bucket struct {
+		count int
+		reset time.Time
+	}
+
+	var mu sync.Mutex
+	buckets := make(map[string]*bucket)
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+			userID := interactionUserID(evt)
+			if userID != "" {
+				mu.Lock()
+				now := time.Now()
+				for id, b := range buckets {
+					if id != userID && now.After(b.reset) {
+						delete(buckets, id)
+					}
+				}
+
+				b, ok := buckets[userID]
+				if !ok || now.After(b.reset) {
+					b = &bucket{count: 0, reset: now.Add(per)}
+					buckets[userID] = b
+				}
+				b.count++
+				limited := b.count > limit
+				mu.Unlock()
+
+				if limited {
+					return ephemeralMessage(evt, "You're doing that too much. Please try again in a moment."), nil
+				}
+			}
+
+			return next.Handle(ctx, evt)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package lambdinte
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	var order []string
+
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return HandlerFunc(func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+				order = append(order, name)
+				return next.Handle(ctx, evt)
+			})
+		}
+	}
+
+	base := HandlerFunc(func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		order = append(order, "handler")
+		return discordgo.InteractionResponse{}, nil
+	})
+
+	h := chain([]Middleware{record("outer"), record("inner")}, base)
+	_, err := h.Handle(context.Background(), discordgo.Interaction{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"outer", "inner", "handler"}
+	if len(order) != len(want) {
+		t.Fatalf("got order %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("got order %v, want %v", order, want)
+		}
+	}
+}
+
+func TestApplicationCommandMuxUse(t *testing.T) {
+	var order []string
+
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return HandlerFunc(func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+				order = append(order, name)
+				return next.Handle(ctx, evt)
+			})
+		}
+	}
+
+	var mux ApplicationCommandMux
+	mux.Use(record("mux"))
+	mux.With(record("scoped")).RegisterFunc("ping", func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		order = append(order, "handler")
+		return discordgo.InteractionResponse{}, nil
+	})
+
+	evt := discordgo.Interaction{
+		Type: discordgo.InteractionApplicationCommand,
+		Data: discordgo.ApplicationCommandInteractionData{Name: "ping"},
+	}
+	_, err := mux.Handle(context.Background(), evt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"mux", "scoped", "handler"}
+	if len(order) != len(want) {
+		t.Fatalf("got order %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("got order %v, want %v", order, want)
+		}
+	}
+}
+
+func TestRecoverMiddlewareRecoversFromPanic(t *testing.T) {
+	h := RecoverMiddleware()(HandlerFunc(func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		panic("boom")
+	}))
+
+	res, err := h.Handle(context.Background(), discordgo.Interaction{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Type != discordgo.InteractionResponseChannelMessageWithSource {
+		t.Fatalf("got response type %v, want %v", res.Type, discordgo.InteractionResponseChannelMessageWithSource)
+	}
+}
+
+func TestRecoverMiddlewareRecoversFromPanicDuringAutocomplete(t *testing.T) {
+	h := RecoverMiddleware()(HandlerFunc(func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		panic("boom")
+	}))
+
+	res, err := h.Handle(context.Background(), discordgo.Interaction{Type: discordgo.InteractionApplicationCommandAutocomplete})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Type != discordgo.InteractionApplicationCommandAutocompleteResult {
+		t.Fatalf("got response type %v, want %v", res.Type, discordgo.InteractionApplicationCommandAutocompleteResult)
+	}
+}
+
+func TestRecoverMiddlewarePassesThroughWithoutPanic(t *testing.T) {
+	want := discordgo.InteractionResponse{Data: &discordgo.InteractionResponseData{Content: "pong"}}
+	h := RecoverMiddleware()(HandlerFunc(func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		return want, nil
+	}))
+
+	res, err := h.Handle(context.Background(), discordgo.Interaction{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Data.Content != "pong" {
+		t.Fatalf("got content %q, want %q", res.Data.Content, "pong")
+	}
+}
+
+func TestLoggingMiddlewarePassesThroughResultAndError(t *testing.T) {
+	want := discordgo.InteractionResponse{Data: &discordgo.InteractionResponseData{Content: "pong"}}
+	wantErr := errors.New("handler failed")
+	h := LoggingMiddleware()(HandlerFunc(func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		return want, wantErr
+	}))
+
+	res, err := h.Handle(context.Background(), discordgo.Interaction{})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("got error %v, want %v", err, wantErr)
+	}
+	if res.Data.Content != "pong" {
+		t.Fatalf("got content %q, want %q", res.Data.Content, "pong")
+	}
+}
+
+func TestRateLimitMiddlewareRejectsOverLimit(t *testing.T) {
+	h := RateLimitMiddleware(2, time.Minute)(HandlerFunc(func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		return discordgo.InteractionResponse{Data: &discordgo.InteractionResponseData{Content: "pong"}}, nil
+	}))
+
+	evt := discordgo.Interaction{User: &discordgo.User{ID: "user-1"}}
+
+	for i := 0; i < 2; i++ {
+		res, err := h.Handle(context.Background(), evt)
+		if err != nil {
+			t.Fatalf("unexpected error on request %d: %v", i, err)
+		}
+		if res.Data.Content != "pong" {
+			t.Fatalf("request %d: got content %q, want %q", i, res.Data.Content, "pong")
+		}
+	}
+
+	res, err := h.Handle(context.Background(), evt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Type != discordgo.InteractionResponseChannelMessageWithSource {
+		t.Fatalf("got response type %v, want %v", res.Type, discordgo.InteractionResponseChannelMessageWithSource)
+	}
+	if res.Data.Content == "pong" {
+		t.Fatal("expected the third request to be rate limited, but it reached the handler")
+	}
+}
+
+func TestRateLimitMiddlewareRejectsAutocompleteWithValidType(t *testing.T) {
+	h := RateLimitMiddleware(0, time.Minute)(HandlerFunc(func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		t.Fatal("handler should not be reached once rate limited")
+		return discordgo.InteractionResponse{}, nil
+	}))
+
+	evt := discordgo.Interaction{
+		Type: discordgo.InteractionApplicationCommandAutocomplete,
+		User: &discordgo.User{ID: "user-1"},
+	}
+
+	res, err := h.Handle(context.Background(), evt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Type != discordgo.InteractionApplicationCommandAutocompleteResult {
+		t.Fatalf("got response type %v, want %v", res.Type, discordgo.InteractionApplicationCommandAutocompleteResult)
+	}
+}
+
+func TestRateLimitMiddlewareTracksUsersSeparately(t *testing.T) {
+	h := RateLimitMiddleware(1, time.Minute)(HandlerFunc(func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		return discordgo.InteractionResponse{Data: &discordgo.InteractionResponseData{Content: "pong"}}, nil
+	}))
+
+	for _, userID := range []string{"user-1", "user-2"} {
+		res, err := h.Handle(context.Background(), discordgo.Interaction{User: &discordgo.User{ID: userID}})
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", userID, err)
+		}
+		if res.Data.Content != "pong" {
+			t.Fatalf("%s: got content %q, want %q", userID, res.Data.Content, "pong")
+		}
+	}
+}
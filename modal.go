@@ -0,0 +1,105 @@
+package lambdinte
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ModalValues flattens the TextInput components of a modal submission to a map from their custom_id to the
+// submitted value, so callers don't have to walk each ActionsRow and type-assert its children themselves.
+func ModalValues(evt discordgo.Interaction) map[string]string {
+	values := make(map[string]string)
+	for _, component := range evt.ModalSubmitData().Components {
+		row, ok := component.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, rowComponent := range row.Components {
+			if input, ok := rowComponent.(*discordgo.TextInput); ok {
+				values[input.CustomID] = input.Value
+			}
+		}
+	}
+	return values
+}
+
+// SelectedValues returns the values chosen in a select menu component interaction.
+func SelectedValues(evt discordgo.Interaction) []string {
+	return evt.MessageComponentData().Values
+}
+
+// bindTag is the struct tag key Bind reads to match a field to a submitted custom_id.
+const bindTag = "lambdinte"
+
+// Bind populates the exported fields of dst, which must be a pointer to a struct, from evt's modal submission
+// (see ModalValues), matching each field to a custom_id via its `lambdinte` struct tag. Supported field types
+// are string, int and the sized signed int types, bool, and time.Time (parsed as RFC3339). Fields with no tag, a "-" tag, or no
+// matching submitted value are left unchanged.
+func Bind(evt discordgo.Interaction, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("lambdinte: Bind called with %T, want a pointer to a struct", dst)
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	values := ModalValues(evt)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get(bindTag)
+		if tag == "" || tag == "-" {
+			continue
+		}
+		if !v.Field(i).CanSet() {
+			continue
+		}
+
+		value, ok := values[tag]
+		if !ok {
+			continue
+		}
+
+		if err := bindField(v.Field(i), value); err != nil {
+			return fmt.Errorf("lambdinte: field %s: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func bindField(field reflect.Value, value string) error {
+	if field.Type() == reflect.TypeOf(time.Time{}) {
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
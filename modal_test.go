@@ -0,0 +1,128 @@
+package lambdinte
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestModalValues(t *testing.T) {
+	evt := discordgo.Interaction{
+		Type: discordgo.InteractionModalSubmit,
+		Data: discordgo.ModalSubmitInteractionData{
+			CustomID: "signup",
+			Components: []discordgo.MessageComponent{
+				&discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						&discordgo.TextInput{CustomID: "name", Value: "Ada"},
+					},
+				},
+				&discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						&discordgo.TextInput{CustomID: "age", Value: "36"},
+					},
+				},
+			},
+		},
+	}
+
+	values := ModalValues(evt)
+	if values["name"] != "Ada" || values["age"] != "36" {
+		t.Fatalf("got %v, want name=Ada age=36", values)
+	}
+}
+
+func TestSelectedValues(t *testing.T) {
+	evt := discordgo.Interaction{
+		Type: discordgo.InteractionMessageComponent,
+		Data: discordgo.MessageComponentInteractionData{
+			CustomID: "pick",
+			Values:   []string{"a", "b"},
+		},
+	}
+
+	got := SelectedValues(evt)
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("got %v, want [a b]", got)
+	}
+}
+
+func TestBind(t *testing.T) {
+	evt := discordgo.Interaction{
+		Type: discordgo.InteractionModalSubmit,
+		Data: discordgo.ModalSubmitInteractionData{
+			Components: []discordgo.MessageComponent{
+				&discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						&discordgo.TextInput{CustomID: "name", Value: "Ada"},
+						&discordgo.TextInput{CustomID: "age", Value: "36"},
+						&discordgo.TextInput{CustomID: "subscribed", Value: "true"},
+						&discordgo.TextInput{CustomID: "born", Value: "1815-12-10T00:00:00Z"},
+						&discordgo.TextInput{CustomID: "ignored", Value: "nope"},
+					},
+				},
+			},
+		},
+	}
+
+	var dst struct {
+		Name       string    `lambdinte:"name"`
+		Age        int       `lambdinte:"age"`
+		Subscribed bool      `lambdinte:"subscribed"`
+		Born       time.Time `lambdinte:"born"`
+		Ignored    string
+	}
+	if err := Bind(evt, &dst); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if dst.Name != "Ada" {
+		t.Errorf("got Name %q, want Ada", dst.Name)
+	}
+	if dst.Age != 36 {
+		t.Errorf("got Age %d, want 36", dst.Age)
+	}
+	if !dst.Subscribed {
+		t.Errorf("got Subscribed false, want true")
+	}
+	want := time.Date(1815, time.December, 10, 0, 0, 0, 0, time.UTC)
+	if !dst.Born.Equal(want) {
+		t.Errorf("got Born %v, want %v", dst.Born, want)
+	}
+	if dst.Ignored != "" {
+		t.Errorf("got Ignored %q, want untouched empty string", dst.Ignored)
+	}
+}
+
+func TestBindSkipsUnexportedFields(t *testing.T) {
+	evt := discordgo.Interaction{
+		Type: discordgo.InteractionModalSubmit,
+		Data: discordgo.ModalSubmitInteractionData{
+			Components: []discordgo.MessageComponent{
+				&discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						&discordgo.TextInput{CustomID: "name", Value: "Ada"},
+					},
+				},
+			},
+		},
+	}
+
+	var dst struct {
+		name string `lambdinte:"name"`
+	}
+	if err := Bind(evt, &dst); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dst.name != "" {
+		t.Errorf("got name %q, want untouched empty string", dst.name)
+	}
+}
+
+func TestBindRejectsNonStructPointer(t *testing.T) {
+	var dst string
+	if err := Bind(discordgo.Interaction{Data: discordgo.ModalSubmitInteractionData{}}, &dst); err == nil {
+		t.Fatal("expected an error binding into a non-struct pointer")
+	}
+}
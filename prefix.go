@@ -0,0 +1,70 @@
+package lambdinte
+
+import (
+	"context"
+	"strings"
+)
+
+// CustomIDDelimiter separates the prefix registered with RegisterPrefix from the state encoded in the
+// rest of a custom_id, and separates that state into the segments returned by CustomIDParams.
+const CustomIDDelimiter = ":"
+
+type customIDParamsKey struct{}
+
+// CustomIDParams returns the segments of a custom_id following the prefix matched by RegisterPrefix, split on
+// CustomIDDelimiter. It returns nil if ctx was not derived from a prefix-routed interaction.
+func CustomIDParams(ctx context.Context) []string {
+	params, _ := ctx.Value(customIDParamsKey{}).([]string)
+	return params
+}
+
+type prefixEntry struct {
+	prefix  string
+	handler Handler
+}
+
+// prefixMux matches custom_ids against a set of registered prefixes, for embedding into MessageComponentMux
+// and ModalSubmitMux.
+type prefixMux struct {
+	prefixes []prefixEntry
+}
+
+// registerPrefix registers handler for customID values beginning with prefix.
+// If a handler already exists for prefix, registerPrefix panics.
+func (p *prefixMux) registerPrefix(prefix string, handler Handler) {
+	if handler == nil {
+		panic("lambdinte: nil handler")
+	}
+	for _, e := range p.prefixes {
+		if e.prefix == prefix {
+			panic("lambdinte: multiple registrations for prefix " + prefix)
+		}
+	}
+
+	p.prefixes = append(p.prefixes, prefixEntry{prefix, handler})
+}
+
+// matchPrefix finds the longest registered prefix matching customID (either exactly, or followed by
+// CustomIDDelimiter), and returns its handler along with the delimiter-separated segments of customID
+// that followed the prefix.
+func (p *prefixMux) matchPrefix(customID string) (handler Handler, params []string, ok bool) {
+	var best *prefixEntry
+	for i := range p.prefixes {
+		e := &p.prefixes[i]
+		if customID != e.prefix && !strings.HasPrefix(customID, e.prefix+CustomIDDelimiter) {
+			continue
+		}
+		if best == nil || len(e.prefix) > len(best.prefix) {
+			best = e
+		}
+	}
+	if best == nil {
+		return nil, nil, false
+	}
+
+	rest := strings.TrimPrefix(strings.TrimPrefix(customID, best.prefix), CustomIDDelimiter)
+	if rest != "" {
+		params = strings.Split(rest, CustomIDDelimiter)
+	}
+	return best.handler, params, true
+}
@@ -0,0 +1,85 @@
+package lambdinte
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func handleComponent(t *testing.T, mux *MessageComponentMux, customID string) (discordgo.InteractionResponse, []string) {
+	t.Helper()
+
+	var params []string
+	mux.RegisterPrefixFunc("capture", func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		params = CustomIDParams(ctx)
+		return discordgo.InteractionResponse{}, nil
+	})
+
+	res, err := mux.Handle(context.Background(), discordgo.Interaction{
+		Type: discordgo.InteractionMessageComponent,
+		Data: discordgo.MessageComponentInteractionData{CustomID: customID},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return res, params
+}
+
+func TestMessageComponentMuxExactTakesPrecedence(t *testing.T) {
+	var mux MessageComponentMux
+	var which string
+	mux.RegisterFunc("vote:proposal-42:yes", func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		which = "exact"
+		return discordgo.InteractionResponse{}, nil
+	})
+	mux.RegisterPrefixFunc("vote", func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		which = "prefix"
+		return discordgo.InteractionResponse{}, nil
+	})
+
+	_, err := mux.Handle(context.Background(), discordgo.Interaction{
+		Type: discordgo.InteractionMessageComponent,
+		Data: discordgo.MessageComponentInteractionData{CustomID: "vote:proposal-42:yes"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if which != "exact" {
+		t.Fatalf("got %q, want exact match to win", which)
+	}
+}
+
+func TestMessageComponentMuxLongestPrefixWins(t *testing.T) {
+	var mux MessageComponentMux
+	var which string
+	mux.RegisterPrefixFunc("vote", func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		which = "vote"
+		return discordgo.InteractionResponse{}, nil
+	})
+	mux.RegisterPrefixFunc("vote:proposal-42", func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		which = "vote:proposal-42"
+		return discordgo.InteractionResponse{}, nil
+	})
+
+	_, err := mux.Handle(context.Background(), discordgo.Interaction{
+		Type: discordgo.InteractionMessageComponent,
+		Data: discordgo.MessageComponentInteractionData{CustomID: "vote:proposal-42:yes"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if which != "vote:proposal-42" {
+		t.Fatalf("got %q, want longest prefix to win", which)
+	}
+}
+
+func TestMessageComponentMuxPrefixParams(t *testing.T) {
+	var mux MessageComponentMux
+	_, params := handleComponent(t, &mux, "capture:proposal-42:yes")
+	want := []string{"proposal-42", "yes"}
+	if !reflect.DeepEqual(params, want) {
+		t.Fatalf("got params %v, want %v", params, want)
+	}
+}
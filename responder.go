@@ -0,0 +1,77 @@
+package lambdinte
+
+import (
+	"context"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type responderKey struct{}
+
+// FromContext returns the Responder available for acknowledging and following up on the interaction being
+// handled, or nil if the Function handling it has no BotToken configured.
+func FromContext(ctx context.Context) *Responder {
+	r, _ := ctx.Value(responderKey{}).(*Responder)
+	return r
+}
+
+// Responder lets a Handler defer its initial acknowledgement of an interaction and later edit or follow up on
+// it using Discord's webhook endpoints (/webhooks/{application_id}/{interaction_token}), for processing that
+// can't finish within Discord's 3-second response window. Obtain one from FromContext.
+type Responder struct {
+	session     *discordgo.Session
+	interaction *discordgo.Interaction
+}
+
+// Defer builds the response a Handler should return from Handle to acknowledge evt, letting a DeferredHandler
+// keep working past Discord's 3-second response window. flags is typically 0 or discordgo.MessageFlagsEphemeral.
+func (r *Responder) Defer(flags discordgo.MessageFlags) discordgo.InteractionResponse {
+	responseType := discordgo.InteractionResponseDeferredChannelMessageWithSource
+	if r.interaction.Type == discordgo.InteractionMessageComponent {
+		responseType = discordgo.InteractionResponseDeferredMessageUpdate
+	}
+
+	return discordgo.InteractionResponse{
+		Type: responseType,
+		Data: &discordgo.InteractionResponseData{Flags: uint64(flags)},
+	}
+}
+
+// Followup sends a new follow-up message for the interaction.
+func (r *Responder) Followup(params *discordgo.WebhookParams) (*discordgo.Message, error) {
+	return r.session.FollowupMessageCreate(r.interaction, true, params)
+}
+
+// EditOriginal edits the interaction's original (deferred) response.
+func (r *Responder) EditOriginal(edit *discordgo.WebhookEdit) (*discordgo.Message, error) {
+	return r.session.InteractionResponseEdit(r.interaction, edit)
+}
+
+// DeleteOriginal deletes the interaction's original response.
+func (r *Responder) DeleteOriginal() error {
+	return r.session.InteractionResponseDelete(r.interaction)
+}
+
+// EditFollowup edits a previously sent follow-up message, identified by messageID.
+func (r *Responder) EditFollowup(messageID string, edit *discordgo.WebhookEdit) (*discordgo.Message, error) {
+	return r.session.FollowupMessageEdit(r.interaction, messageID, edit)
+}
+
+// DeferredHandler is implemented by a Handler that wants to keep processing an interaction after
+// acknowledging it with a deferred response (see Responder.Defer). If the Function has a BotToken and the
+// Handler that served the interaction — Function.Handler itself, or a handler registered on a Mux it
+// dispatches to — implements DeferredHandler, Function.invoke starts HandleDeferred in a goroutine right
+// after Handle returns without an error, and does not wait for it: the deferred response is sent back as the Lambda invocation's result immediately, so it
+// reaches Discord within its 3-second window regardless of how long HandleDeferred goes on to take.
+//
+// This comes at a real cost: Start uses the classic, non-streaming Lambda Go runtime, which considers the
+// invocation finished as soon as it has that result, and AWS Lambda is free to freeze or recycle the
+// execution environment at that point. A frozen goroutine may simply resume on the next invocation and
+// finish late, or it may be recycled away and never run its Followup/EditOriginal/EditFollowup calls at all —
+// there is no guarantee either way. Reserve HandleDeferred for work where a missed follow-up is acceptable;
+// if you need a hard delivery guarantee, hand the work off to something that outlives this invocation (e.g.
+// an SQS message or a second, asynchronously-invoked Lambda function) instead of relying on this goroutine.
+type DeferredHandler interface {
+	Handler
+	HandleDeferred(ctx context.Context, evt discordgo.Interaction, r *Responder)
+}
@@ -0,0 +1,87 @@
+package lambdinte
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// subcommandKey builds the map key under which a subcommand or subcommand group handler is stored.
+// group is empty for a bare subcommand (no group).
+func subcommandKey(cmd, group, sub string) string {
+	return cmd + "\x00" + group + "\x00" + sub
+}
+
+// subcommandPath inspects data's options to find a nested subcommand or subcommand group invocation.
+// It returns the subcommand group name (empty if the command wasn't invoked through a group), the
+// subcommand name (empty if the command wasn't invoked through a subcommand at all), and the options
+// that were passed to the leaf subcommand.
+func subcommandPath(data discordgo.ApplicationCommandInteractionData) (group, sub string, options []*discordgo.ApplicationCommandInteractionDataOption) {
+	if len(data.Options) != 1 {
+		return "", "", data.Options
+	}
+
+	switch opt := data.Options[0]; opt.Type {
+	case discordgo.ApplicationCommandOptionSubCommandGroup:
+		if len(opt.Options) != 1 || opt.Options[0].Type != discordgo.ApplicationCommandOptionSubCommand {
+			return "", "", data.Options
+		}
+		return opt.Name, opt.Options[0].Name, opt.Options[0].Options
+	case discordgo.ApplicationCommandOptionSubCommand:
+		return "", opt.Name, opt.Options
+	default:
+		return "", "", data.Options
+	}
+}
+
+// RegisterSubcommand registers the handler for a subcommand of cmd, e.g. the "set" in "/config set key value".
+// If a handler already exists for this command and subcommand, RegisterSubcommand panics.
+func (m *ApplicationCommandMux) RegisterSubcommand(cmd, sub string, handler Handler) {
+	m.registerSubcommand(cmd, "", sub, handler)
+}
+
+// RegisterSubcommandFunc registers the handler function for a subcommand of cmd.
+func (m *ApplicationCommandMux) RegisterSubcommandFunc(cmd, sub string, handler func(context.Context, discordgo.Interaction) (discordgo.InteractionResponse, error)) {
+	m.RegisterSubcommand(cmd, sub, HandlerFunc(handler))
+}
+
+// RegisterSubcommandGroup registers the handler for a subcommand belonging to a subcommand group of cmd,
+// e.g. the "set" in "/config permissions set role value" where "permissions" is the group.
+// If a handler already exists for this command, group, and subcommand, RegisterSubcommandGroup panics.
+func (m *ApplicationCommandMux) RegisterSubcommandGroup(cmd, group, sub string, handler Handler) {
+	m.registerSubcommand(cmd, group, sub, handler)
+}
+
+// RegisterSubcommandGroupFunc registers the handler function for a subcommand belonging to a subcommand group of cmd.
+func (m *ApplicationCommandMux) RegisterSubcommandGroupFunc(cmd, group, sub string, handler func(context.Context, discordgo.Interaction) (discordgo.InteractionResponse, error)) {
+	m.RegisterSubcommandGroup(cmd, group, sub, HandlerFunc(handler))
+}
+
+func (m *ApplicationCommandMux) registerSubcommand(cmd, group, sub string, handler Handler) {
+	if handler == nil {
+		panic("lambdinte: nil handler")
+	}
+
+	key := subcommandKey(cmd, group, sub)
+	if _, exists := m.subcommands[key]; exists {
+		panic("lambdinte: multiple registrations for " + subcommandDescription(cmd, group, sub))
+	}
+	if m.subcommands == nil {
+		m.subcommands = make(map[string]Handler)
+	}
+
+	m.subcommands[key] = chain(m.mw, handler)
+}
+
+// subcommandDescription renders cmd, group, and sub as a human-readable space-separated path (e.g.
+// "config permissions set"), for use in error messages. Unlike subcommandKey, it's never parsed back.
+func subcommandDescription(cmd, group, sub string) string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{cmd, group, sub} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,132 @@
+package lambdinte
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func handleCommand(t *testing.T, mux *ApplicationCommandMux, data discordgo.ApplicationCommandInteractionData) discordgo.InteractionResponse {
+	t.Helper()
+
+	res, err := mux.Handle(context.Background(), discordgo.Interaction{
+		Type: discordgo.InteractionApplicationCommand,
+		Data: data,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return res
+}
+
+func TestApplicationCommandMuxFlat(t *testing.T) {
+	var mux ApplicationCommandMux
+	mux.RegisterFunc("ping", func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		return discordgo.InteractionResponse{Data: &discordgo.InteractionResponseData{Content: "pong"}}, nil
+	})
+
+	res := handleCommand(t, &mux, discordgo.ApplicationCommandInteractionData{Name: "ping"})
+	if res.Data.Content != "pong" {
+		t.Fatalf("got content %q, want %q", res.Data.Content, "pong")
+	}
+}
+
+func TestApplicationCommandMuxSubcommand(t *testing.T) {
+	var mux ApplicationCommandMux
+	mux.RegisterSubcommandFunc("config", "set", func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		data := evt.ApplicationCommandData()
+		if data.Name != "set" {
+			t.Errorf("got leaf name %q, want %q", data.Name, "set")
+		}
+		if len(data.Options) != 1 || data.Options[0].Name != "key" {
+			t.Errorf("got leaf options %v, want one option named key", data.Options)
+		}
+		return discordgo.InteractionResponse{Data: &discordgo.InteractionResponseData{Content: "set"}}, nil
+	})
+
+	res := handleCommand(t, &mux, discordgo.ApplicationCommandInteractionData{
+		Name: "config",
+		Options: []*discordgo.ApplicationCommandInteractionDataOption{
+			{
+				Name: "set",
+				Type: discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{Name: "key", Type: discordgo.ApplicationCommandOptionString},
+				},
+			},
+		},
+	})
+	if res.Data.Content != "set" {
+		t.Fatalf("got content %q, want %q", res.Data.Content, "set")
+	}
+}
+
+func TestApplicationCommandMuxSubcommandGroup(t *testing.T) {
+	var mux ApplicationCommandMux
+	mux.RegisterSubcommandGroupFunc("config", "permissions", "set", func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		data := evt.ApplicationCommandData()
+		if data.Name != "set" {
+			t.Errorf("got leaf name %q, want %q", data.Name, "set")
+		}
+		return discordgo.InteractionResponse{Data: &discordgo.InteractionResponseData{Content: "group-set"}}, nil
+	})
+
+	res := handleCommand(t, &mux, discordgo.ApplicationCommandInteractionData{
+		Name: "config",
+		Options: []*discordgo.ApplicationCommandInteractionDataOption{
+			{
+				Name: "permissions",
+				Type: discordgo.ApplicationCommandOptionSubCommandGroup,
+				Options: []*discordgo.ApplicationCommandInteractionDataOption{
+					{Name: "set", Type: discordgo.ApplicationCommandOptionSubCommand},
+				},
+			},
+		},
+	})
+	if res.Data.Content != "group-set" {
+		t.Fatalf("got content %q, want %q", res.Data.Content, "group-set")
+	}
+}
+
+func TestApplicationCommandMuxDuplicateSubcommandGroupPanicMessage(t *testing.T) {
+	var mux ApplicationCommandMux
+	mux.RegisterSubcommandGroupFunc("config", "permissions", "set", func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		return discordgo.InteractionResponse{}, nil
+	})
+
+	defer func() {
+		p := recover()
+		msg, ok := p.(string)
+		if !ok {
+			t.Fatalf("got panic value %v, want a string", p)
+		}
+		if want := "config permissions set"; !strings.Contains(msg, want) {
+			t.Fatalf("panic message %q doesn't mention %q", msg, want)
+		}
+		if strings.ContainsRune(msg, '\x00') {
+			t.Fatalf("panic message %q contains a raw NUL byte", msg)
+		}
+	}()
+	mux.RegisterSubcommandGroupFunc("config", "permissions", "set", func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		return discordgo.InteractionResponse{}, nil
+	})
+}
+
+func TestApplicationCommandMuxSubcommandFallback(t *testing.T) {
+	var mux ApplicationCommandMux
+	mux.RegisterFunc("config", func(ctx context.Context, evt discordgo.Interaction) (discordgo.InteractionResponse, error) {
+		return discordgo.InteractionResponse{Data: &discordgo.InteractionResponseData{Content: "top-level"}}, nil
+	})
+
+	res := handleCommand(t, &mux, discordgo.ApplicationCommandInteractionData{
+		Name: "config",
+		Options: []*discordgo.ApplicationCommandInteractionDataOption{
+			{Name: "set", Type: discordgo.ApplicationCommandOptionSubCommand},
+		},
+	})
+	if res.Data.Content != "top-level" {
+		t.Fatalf("got content %q, want %q", res.Data.Content, "top-level")
+	}
+}